@@ -0,0 +1,32 @@
+package v1alpha1
+
+import "fmt"
+
+// Database defines the database connection rbd-chaos (and other region components) use.
+type Database struct {
+	Name     string `json:"name,omitempty"`
+	Host     string `json:"host,omitempty"`
+	Port     int    `json:"port,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// RegionDataSource returns the DSN arg passed to region components on the command line.
+func (in *Database) RegionDataSource() string {
+	return fmt.Sprintf("--mysql=%s:%s@tcp(%s:%d)/%s", in.Username, in.Password, in.Host, in.Port, in.Name)
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out.
+func (in *Database) DeepCopyInto(out *Database) {
+	*out = *in
+}
+
+// DeepCopy copies the receiver, creating a new Database.
+func (in *Database) DeepCopy() *Database {
+	if in == nil {
+		return nil
+	}
+	out := new(Database)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,197 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// MavenServer configures the credentials Maven uses for a <server> entry in settings.xml.
+// The credentials themselves are never stored on the CR: SecretRef points at a Secret in the
+// same namespace holding them under the "username"/"password" keys, and is resolved by the
+// mavensetting controller/admission webhook before settings.xml is rendered.
+type MavenServer struct {
+	ID        string                      `json:"id"`
+	SecretRef corev1.LocalObjectReference `json:"secretRef"`
+}
+
+// MavenMirror configures a <mirror> entry in settings.xml.
+type MavenMirror struct {
+	ID       string `json:"id"`
+	MirrorOf string `json:"mirrorOf"`
+	Name     string `json:"name,omitempty"`
+	URL      string `json:"url"`
+}
+
+// MavenRepository configures a <repository> entry nested under a MavenProfile.
+type MavenRepository struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+// MavenProfile configures a <profile> entry in settings.xml.
+type MavenProfile struct {
+	ID           string            `json:"id"`
+	Repositories []MavenRepository `json:"repositories,omitempty"`
+}
+
+// MavenSettingSpec defines the desired state of a MavenSetting.
+type MavenSettingSpec struct {
+	Servers []MavenServer `json:"servers,omitempty"`
+	Mirrors []MavenMirror `json:"mirrors,omitempty"`
+
+	Profiles       []MavenProfile `json:"profiles,omitempty"`
+	ActiveProfiles []string       `json:"activeProfiles,omitempty"`
+
+	// Default marks this CR's rendered settings.xml as the one builds use when a tenant
+	// does not select a specific MavenSetting.
+	Default bool `json:"default,omitempty"`
+}
+
+// MavenSettingStatus defines the observed state of a MavenSetting.
+type MavenSettingStatus struct{}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MavenSetting is the Schema for the mavensettings API.
+type MavenSetting struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MavenSettingSpec   `json:"spec,omitempty"`
+	Status MavenSettingStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MavenSettingList contains a list of MavenSetting.
+type MavenSettingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MavenSetting `json:"items"`
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out.
+func (in *MavenSettingSpec) DeepCopyInto(out *MavenSettingSpec) {
+	*out = *in
+	if in.Servers != nil {
+		out.Servers = make([]MavenServer, len(in.Servers))
+		copy(out.Servers, in.Servers)
+	}
+	if in.Mirrors != nil {
+		out.Mirrors = make([]MavenMirror, len(in.Mirrors))
+		copy(out.Mirrors, in.Mirrors)
+	}
+	if in.Profiles != nil {
+		out.Profiles = make([]MavenProfile, len(in.Profiles))
+		for i := range in.Profiles {
+			in.Profiles[i].DeepCopyInto(&out.Profiles[i])
+		}
+	}
+	if in.ActiveProfiles != nil {
+		out.ActiveProfiles = make([]string, len(in.ActiveProfiles))
+		copy(out.ActiveProfiles, in.ActiveProfiles)
+	}
+}
+
+// DeepCopy copies the receiver, creating a new MavenSettingSpec.
+func (in *MavenSettingSpec) DeepCopy() *MavenSettingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MavenSettingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out.
+func (in *MavenProfile) DeepCopyInto(out *MavenProfile) {
+	*out = *in
+	if in.Repositories != nil {
+		out.Repositories = make([]MavenRepository, len(in.Repositories))
+		copy(out.Repositories, in.Repositories)
+	}
+}
+
+// DeepCopy copies the receiver, creating a new MavenProfile.
+func (in *MavenProfile) DeepCopy() *MavenProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(MavenProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out.
+func (in *MavenSettingStatus) DeepCopyInto(out *MavenSettingStatus) {
+	*out = *in
+}
+
+// DeepCopy copies the receiver, creating a new MavenSettingStatus.
+func (in *MavenSettingStatus) DeepCopy() *MavenSettingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MavenSettingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out.
+func (in *MavenSetting) DeepCopyInto(out *MavenSetting) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy copies the receiver, creating a new MavenSetting.
+func (in *MavenSetting) DeepCopy() *MavenSetting {
+	if in == nil {
+		return nil
+	}
+	out := new(MavenSetting)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *MavenSetting) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out.
+func (in *MavenSettingList) DeepCopyInto(out *MavenSettingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]MavenSetting, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy copies the receiver, creating a new MavenSettingList.
+func (in *MavenSettingList) DeepCopy() *MavenSettingList {
+	if in == nil {
+		return nil
+	}
+	out := new(MavenSettingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *MavenSettingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
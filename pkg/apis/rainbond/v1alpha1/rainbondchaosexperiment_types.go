@@ -0,0 +1,227 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ExperimentType is the kind of fault a RainbondChaosExperiment injects.
+type ExperimentType string
+
+const (
+	ExperimentTypePodKill        ExperimentType = "pod-kill"
+	ExperimentTypeNetworkLatency ExperimentType = "network-latency"
+	ExperimentTypeDiskFill       ExperimentType = "disk-fill"
+	ExperimentTypeCPUHog         ExperimentType = "cpu-hog"
+)
+
+// ChaosExperimentPhase is the current lifecycle phase of a RainbondChaosExperiment run.
+type ChaosExperimentPhase string
+
+const (
+	ChaosExperimentPhasePending   ChaosExperimentPhase = "Pending"
+	ChaosExperimentPhaseRunning   ChaosExperimentPhase = "Running"
+	ChaosExperimentPhaseCompleted ChaosExperimentPhase = "Completed"
+	ChaosExperimentPhaseFailed    ChaosExperimentPhase = "Failed"
+)
+
+// ProbeType is the mechanism a Probe uses to judge experiment success.
+type ProbeType string
+
+const (
+	ProbeTypeHTTPGet  ProbeType = "httpGet"
+	ProbeTypeCmdProbe ProbeType = "cmdProbe"
+)
+
+// Probe validates that the target application survived an experiment.
+type Probe struct {
+	Name string    `json:"name"`
+	Type ProbeType `json:"type"`
+
+	// HTTPGet is used when Type is httpGet.
+	HTTPGet *HTTPGetProbe `json:"httpGet,omitempty"`
+	// Cmd is used when Type is cmdProbe.
+	Cmd *CmdProbe `json:"cmd,omitempty"`
+
+	// SuccessCondition is evaluated against the probe result, e.g. "==200" for httpGet
+	// status codes or "==0" for a cmdProbe exit code.
+	SuccessCondition string `json:"successCondition,omitempty"`
+}
+
+// HTTPGetProbe performs an HTTP GET against the target application.
+type HTTPGetProbe struct {
+	Path string `json:"path"`
+	Port int32  `json:"port"`
+}
+
+// CmdProbe runs a command against the target application.
+type CmdProbe struct {
+	Command []string `json:"command"`
+}
+
+// RainbondChaosExperimentSpec defines the desired state of a RainbondChaosExperiment.
+type RainbondChaosExperimentSpec struct {
+	ExperimentType ExperimentType `json:"experimentType"`
+
+	// AppSelector selects the Rainbond tenant services the experiment targets.
+	AppSelector *metav1.LabelSelector `json:"appSelector,omitempty"`
+
+	// Duration bounds how long the injected fault runs before being cleaned up.
+	Duration metav1.Duration `json:"duration,omitempty"`
+
+	// Probes validate that the target application survived the experiment.
+	Probes []Probe `json:"probes,omitempty"`
+
+	// Schedule is a cron expression that re-triggers the experiment; a single run is
+	// performed immediately when empty.
+	Schedule string `json:"schedule,omitempty"`
+
+	// NodeNames pins the experiment Pod to specific chaos nodes, mirroring
+	// RainbondCluster.Spec.NodesForChaos.
+	NodeNames []string `json:"nodeNames,omitempty"`
+}
+
+// ProbeResult records the outcome of a single Probe evaluation.
+type ProbeResult struct {
+	Name    string `json:"name"`
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+}
+
+// RainbondChaosExperimentStatus defines the observed state of a RainbondChaosExperiment.
+type RainbondChaosExperimentStatus struct {
+	Phase ChaosExperimentPhase `json:"phase,omitempty"`
+
+	// LastScheduleTime is when the experiment Pod for the current/most recent run was
+	// created, used to decide when Schedule next fires.
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// ProbeResults holds the outcome of each configured Probe for the most recent run.
+	ProbeResults []ProbeResult `json:"probeResults,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RainbondChaosExperiment is the Schema for the rainbondchaosexperiments API.
+type RainbondChaosExperiment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RainbondChaosExperimentSpec   `json:"spec,omitempty"`
+	Status RainbondChaosExperimentStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RainbondChaosExperimentList contains a list of RainbondChaosExperiment.
+type RainbondChaosExperimentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RainbondChaosExperiment `json:"items"`
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out.
+func (in *RainbondChaosExperimentSpec) DeepCopyInto(out *RainbondChaosExperimentSpec) {
+	*out = *in
+	if in.AppSelector != nil {
+		out.AppSelector = in.AppSelector.DeepCopy()
+	}
+	if in.Probes != nil {
+		out.Probes = make([]Probe, len(in.Probes))
+		copy(out.Probes, in.Probes)
+	}
+	if in.NodeNames != nil {
+		out.NodeNames = make([]string, len(in.NodeNames))
+		copy(out.NodeNames, in.NodeNames)
+	}
+}
+
+// DeepCopy copies the receiver, creating a new RainbondChaosExperimentSpec.
+func (in *RainbondChaosExperimentSpec) DeepCopy() *RainbondChaosExperimentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RainbondChaosExperimentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out.
+func (in *RainbondChaosExperimentStatus) DeepCopyInto(out *RainbondChaosExperimentStatus) {
+	*out = *in
+	if in.LastScheduleTime != nil {
+		out.LastScheduleTime = in.LastScheduleTime.DeepCopy()
+	}
+	if in.ProbeResults != nil {
+		out.ProbeResults = make([]ProbeResult, len(in.ProbeResults))
+		copy(out.ProbeResults, in.ProbeResults)
+	}
+}
+
+// DeepCopy copies the receiver, creating a new RainbondChaosExperimentStatus.
+func (in *RainbondChaosExperimentStatus) DeepCopy() *RainbondChaosExperimentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RainbondChaosExperimentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out.
+func (in *RainbondChaosExperiment) DeepCopyInto(out *RainbondChaosExperiment) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy copies the receiver, creating a new RainbondChaosExperiment.
+func (in *RainbondChaosExperiment) DeepCopy() *RainbondChaosExperiment {
+	if in == nil {
+		return nil
+	}
+	out := new(RainbondChaosExperiment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *RainbondChaosExperiment) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out.
+func (in *RainbondChaosExperimentList) DeepCopyInto(out *RainbondChaosExperimentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]RainbondChaosExperiment, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy copies the receiver, creating a new RainbondChaosExperimentList.
+func (in *RainbondChaosExperimentList) DeepCopy() *RainbondChaosExperimentList {
+	if in == nil {
+		return nil
+	}
+	out := new(RainbondChaosExperimentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *RainbondChaosExperimentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// K8sNode identifies a cluster node by name, used to pin chaos/build workloads.
+type K8sNode struct {
+	Name string `json:"name"`
+}
+
+// ImageHub describes the image repository builds push to.
+type ImageHub struct {
+	Domain    string `json:"domain,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Username  string `json:"username,omitempty"`
+	Password  string `json:"password,omitempty"`
+}
+
+// ContainerRuntime identifies the container engine running on a cluster's nodes, used to pick
+// which socket rbd-chaos mounts to drive image builds.
+type ContainerRuntime string
+
+const (
+	ContainerRuntimeDocker     ContainerRuntime = "docker"
+	ContainerRuntimeContainerd ContainerRuntime = "containerd"
+	ContainerRuntimeCRIO       ContainerRuntime = "cri-o"
+)
+
+// RainbondClusterSpec defines the desired state of RainbondCluster.
+type RainbondClusterSpec struct {
+	RegionDatabase *Database `json:"regionDatabase,omitempty"`
+	NodesForChaos  []K8sNode `json:"nodesForChaos,omitempty"`
+	ImageHub       *ImageHub `json:"imageHub,omitempty"`
+
+	// ContainerRuntime overrides auto-detection of the node container runtime. Leave empty
+	// to have the operator's rbd-chaos handler detect it from the nodes' reported container
+	// runtime version.
+	ContainerRuntime ContainerRuntime `json:"containerRuntime,omitempty"`
+	// RuntimeSocketPath overrides the default socket path for ContainerRuntime.
+	RuntimeSocketPath string `json:"runtimeSocketPath,omitempty"`
+}
+
+// RainbondClusterStatus defines the observed state of RainbondCluster.
+type RainbondClusterStatus struct {
+	ImagePullSecret corev1.LocalObjectReference `json:"imagePullSecret,omitempty"`
+
+	// ContainerRuntime is the auto-detected container runtime, set once by the operator's
+	// rbd-chaos handler when Spec.ContainerRuntime is empty.
+	ContainerRuntime ContainerRuntime `json:"containerRuntime,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RainbondCluster is the Schema for the rainbondclusters API.
+type RainbondCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RainbondClusterSpec   `json:"spec,omitempty"`
+	Status RainbondClusterStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RainbondClusterList contains a list of RainbondCluster.
+type RainbondClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RainbondCluster `json:"items"`
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out.
+func (in *RainbondClusterSpec) DeepCopyInto(out *RainbondClusterSpec) {
+	*out = *in
+	if in.RegionDatabase != nil {
+		out.RegionDatabase = in.RegionDatabase.DeepCopy()
+	}
+	if in.NodesForChaos != nil {
+		out.NodesForChaos = make([]K8sNode, len(in.NodesForChaos))
+		copy(out.NodesForChaos, in.NodesForChaos)
+	}
+	if in.ImageHub != nil {
+		out.ImageHub = new(ImageHub)
+		*out.ImageHub = *in.ImageHub
+	}
+}
+
+// DeepCopy copies the receiver, creating a new RainbondClusterSpec.
+func (in *RainbondClusterSpec) DeepCopy() *RainbondClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RainbondClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out.
+func (in *RainbondClusterStatus) DeepCopyInto(out *RainbondClusterStatus) {
+	*out = *in
+}
+
+// DeepCopy copies the receiver, creating a new RainbondClusterStatus.
+func (in *RainbondClusterStatus) DeepCopy() *RainbondClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RainbondClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out.
+func (in *RainbondCluster) DeepCopyInto(out *RainbondCluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy copies the receiver, creating a new RainbondCluster.
+func (in *RainbondCluster) DeepCopy() *RainbondCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(RainbondCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *RainbondCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out.
+func (in *RainbondClusterList) DeepCopyInto(out *RainbondClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]RainbondCluster, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy copies the receiver, creating a new RainbondClusterList.
+func (in *RainbondClusterList) DeepCopy() *RainbondClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(RainbondClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *RainbondClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
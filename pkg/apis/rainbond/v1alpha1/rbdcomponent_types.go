@@ -0,0 +1,198 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// BuildBackend selects the build tooling rbd-chaos uses to turn source code into an image.
+type BuildBackend string
+
+const (
+	// BuildBackendDocker shells out to the Docker daemon over a mounted docker.sock. This
+	// is the long-standing default and requires a docker daemon on every chaos node.
+	BuildBackendDocker BuildBackend = "docker"
+	// BuildBackendBuildkit runs builds through a rootless buildkitd sidecar, with no
+	// dependency on a node-level container engine socket.
+	BuildBackendBuildkit BuildBackend = "buildkit"
+	// BuildBackendKaniko runs each build as a one-shot Kaniko Job instead of a long-lived
+	// sidecar.
+	BuildBackendKaniko BuildBackend = "kaniko"
+)
+
+// RbdComponentSpec defines the desired state of a Rainbond region component.
+type RbdComponentSpec struct {
+	Image           string               `json:"image,omitempty"`
+	ImagePullPolicy corev1.PullPolicy    `json:"imagePullPolicy,omitempty"`
+	Replicas        *int32               `json:"replicas,omitempty"`
+	Env             []corev1.EnvVar      `json:"env,omitempty"`
+	Volumes         []corev1.Volume      `json:"volumes,omitempty"`
+	VolumeMounts    []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+	Args            []string             `json:"args,omitempty"`
+	Resources       corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// BuildBackend selects the build tooling rbd-chaos uses (docker|buildkit|kaniko).
+	// Defaults to docker when empty.
+	BuildBackend BuildBackend `json:"buildBackend,omitempty"`
+}
+
+// TenantStorageUsage reports the storage recorded for a single tenant. Used holds the capacity
+// reported on the tenant's cache PVC (zero while that PVC is missing or unbound); live usage of
+// the volume is not measured.
+type TenantStorageUsage struct {
+	Tenant string            `json:"tenant"`
+	Used   resource.Quantity `json:"used"`
+}
+
+// RbdComponentStatus defines the observed state of a Rainbond region component.
+type RbdComponentStatus struct {
+	// StorageUsage reports, per tenant, the cache PVC capacity when the component
+	// provisions per-tenant storage via StorageProfile CRs.
+	StorageUsage []TenantStorageUsage `json:"storageUsage,omitempty"`
+}
+
+// ImagePullPolicy returns the configured pull policy, defaulting to IfNotPresent.
+func (in *RbdComponent) ImagePullPolicy() corev1.PullPolicy {
+	if in.Spec.ImagePullPolicy != "" {
+		return in.Spec.ImagePullPolicy
+	}
+	return corev1.PullIfNotPresent
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RbdComponent is the Schema for a single Rainbond region component (e.g. rbd-chaos).
+type RbdComponent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RbdComponentSpec   `json:"spec,omitempty"`
+	Status RbdComponentStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RbdComponentList contains a list of RbdComponent.
+type RbdComponentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RbdComponent `json:"items"`
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out.
+func (in *RbdComponentSpec) DeepCopyInto(out *RbdComponentSpec) {
+	*out = *in
+	if in.Replicas != nil {
+		out.Replicas = new(int32)
+		*out.Replicas = *in.Replicas
+	}
+	if in.Env != nil {
+		out.Env = make([]corev1.EnvVar, len(in.Env))
+		copy(out.Env, in.Env)
+	}
+	if in.Volumes != nil {
+		out.Volumes = make([]corev1.Volume, len(in.Volumes))
+		copy(out.Volumes, in.Volumes)
+	}
+	if in.VolumeMounts != nil {
+		out.VolumeMounts = make([]corev1.VolumeMount, len(in.VolumeMounts))
+		copy(out.VolumeMounts, in.VolumeMounts)
+	}
+	if in.Args != nil {
+		out.Args = make([]string, len(in.Args))
+		copy(out.Args, in.Args)
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy copies the receiver, creating a new RbdComponentSpec.
+func (in *RbdComponentSpec) DeepCopy() *RbdComponentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RbdComponentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out.
+func (in *RbdComponentStatus) DeepCopyInto(out *RbdComponentStatus) {
+	*out = *in
+	if in.StorageUsage != nil {
+		out.StorageUsage = make([]TenantStorageUsage, len(in.StorageUsage))
+		for i := range in.StorageUsage {
+			in.StorageUsage[i].Used.DeepCopyInto(&out.StorageUsage[i].Used)
+			out.StorageUsage[i].Tenant = in.StorageUsage[i].Tenant
+		}
+	}
+}
+
+// DeepCopy copies the receiver, creating a new RbdComponentStatus.
+func (in *RbdComponentStatus) DeepCopy() *RbdComponentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RbdComponentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out.
+func (in *RbdComponent) DeepCopyInto(out *RbdComponent) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy copies the receiver, creating a new RbdComponent.
+func (in *RbdComponent) DeepCopy() *RbdComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(RbdComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *RbdComponent) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out.
+func (in *RbdComponentList) DeepCopyInto(out *RbdComponentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]RbdComponent, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy copies the receiver, creating a new RbdComponentList.
+func (in *RbdComponentList) DeepCopy() *RbdComponentList {
+	if in == nil {
+		return nil
+	}
+	out := new(RbdComponentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *RbdComponentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
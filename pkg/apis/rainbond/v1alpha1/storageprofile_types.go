@@ -0,0 +1,146 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// StorageProfileSpec defines the desired state of a StorageProfile, which provisions a
+// dedicated grdata/cache PVC pair for a single tenant rather than the cluster-wide pair every
+// tenant otherwise shares.
+type StorageProfileSpec struct {
+	StorageClassName string `json:"storageClassName,omitempty"`
+	CSIDriver        string `json:"csiDriver,omitempty"`
+
+	// CacheSize bounds the tenant's build cache PVC.
+	CacheSize resource.Quantity `json:"cacheSize"`
+	// GrDataSize bounds the tenant's grdata PVC.
+	GrDataSize resource.Quantity `json:"grDataSize"`
+
+	Parameters   map[string]string `json:"parameters,omitempty"`
+	MountOptions []string          `json:"mountOptions,omitempty"`
+}
+
+// StorageProfileStatus defines the observed state of a StorageProfile.
+type StorageProfileStatus struct{}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// StorageProfile is the Schema for the storageprofiles API.
+type StorageProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   StorageProfileSpec   `json:"spec,omitempty"`
+	Status StorageProfileStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// StorageProfileList contains a list of StorageProfile.
+type StorageProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []StorageProfile `json:"items"`
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out.
+func (in *StorageProfileSpec) DeepCopyInto(out *StorageProfileSpec) {
+	*out = *in
+	in.CacheSize.DeepCopyInto(&out.CacheSize)
+	in.GrDataSize.DeepCopyInto(&out.GrDataSize)
+	if in.Parameters != nil {
+		out.Parameters = make(map[string]string, len(in.Parameters))
+		for k, v := range in.Parameters {
+			out.Parameters[k] = v
+		}
+	}
+	if in.MountOptions != nil {
+		out.MountOptions = make([]string, len(in.MountOptions))
+		copy(out.MountOptions, in.MountOptions)
+	}
+}
+
+// DeepCopy copies the receiver, creating a new StorageProfileSpec.
+func (in *StorageProfileSpec) DeepCopy() *StorageProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageProfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out.
+func (in *StorageProfileStatus) DeepCopyInto(out *StorageProfileStatus) {
+	*out = *in
+}
+
+// DeepCopy copies the receiver, creating a new StorageProfileStatus.
+func (in *StorageProfileStatus) DeepCopy() *StorageProfileStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageProfileStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out.
+func (in *StorageProfile) DeepCopyInto(out *StorageProfile) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy copies the receiver, creating a new StorageProfile.
+func (in *StorageProfile) DeepCopy() *StorageProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *StorageProfile) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out.
+func (in *StorageProfileList) DeepCopyInto(out *StorageProfileList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]StorageProfile, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy copies the receiver, creating a new StorageProfileList.
+func (in *StorageProfileList) DeepCopy() *StorageProfileList {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageProfileList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *StorageProfileList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package mavensetting
+
+import (
+	"context"
+	"fmt"
+
+	rainbondv1alpha1 "github.com/goodrain/rainbond-operator/pkg/apis/rainbond/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+var log = logf.Log.WithName("controller_mavensetting")
+
+// validatingWebhookPath is the path the ValidatingWebhookConfiguration in
+// deploy/webhook/mavensetting_validating_webhook.yaml points the API server at.
+const validatingWebhookPath = "/validate-rainbond-io-v1alpha1-mavensetting"
+
+// Add creates a new MavenSetting Controller and adds it to the Manager. The Manager will set
+// fields on the Controller and Start it when the Manager is Started. It also registers the
+// Validator admission handler with the Manager's webhook server; the matching
+// ValidatingWebhookConfiguration that points the API server at it lives in
+// deploy/webhook/mavensetting_validating_webhook.yaml.
+func Add(mgr manager.Manager) error {
+	mgr.GetWebhookServer().Register(validatingWebhookPath, &webhook.Admission{Handler: &Validator{}})
+	return add(mgr, newReconciler(mgr))
+}
+
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileMavenSetting{client: mgr.GetClient(), scheme: mgr.GetScheme()}
+}
+
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	c, err := controller.New("mavensetting-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	if err := c.Watch(&source.Kind{Type: &rainbondv1alpha1.MavenSetting{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	return c.Watch(&source.Kind{Type: &corev1.Secret{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &rainbondv1alpha1.MavenSetting{},
+	})
+}
+
+// ReconcileMavenSetting reconciles a MavenSetting object into the Secret consumed by builds.
+// It alone owns that Secret; rbd-chaos only ever falls back to its own baked-in default
+// settings.xml ConfigMap when no MavenSetting CR exists.
+type ReconcileMavenSetting struct {
+	client client.Client
+	scheme *runtime.Scheme
+}
+
+// Reconcile resolves the MavenSetting CR's server secretRefs, renders the CR into its Secret,
+// and creates/updates it.
+func (r *ReconcileMavenSetting) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("reconciling MavenSetting")
+
+	ms := &rainbondv1alpha1.MavenSetting{}
+	if err := r.client.Get(context.TODO(), request.NamespacedName, ms); err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	servers, err := resolveServers(context.TODO(), r.client, request.Namespace, ms.Spec.Servers)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("resolve server credentials: %v", err)
+	}
+
+	secret, err := SecretFor(request.Namespace, ms, servers)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if err := controllerutil.SetControllerReference(ms, secret, r.scheme); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	found := &corev1.Secret{}
+	err = r.client.Get(context.TODO(), client.ObjectKey{Namespace: secret.Namespace, Name: secret.Name}, found)
+	if errors.IsNotFound(err) {
+		return reconcile.Result{}, r.client.Create(context.TODO(), secret)
+	} else if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	found.Data = secret.Data
+	return reconcile.Result{}, r.client.Update(context.TODO(), found)
+}
@@ -0,0 +1,149 @@
+package mavensetting
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+
+	rainbondv1alpha1 "github.com/goodrain/rainbond-operator/pkg/apis/rainbond/v1alpha1"
+	"github.com/goodrain/rainbond-operator/pkg/util/rbdutil"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SecretName returns the name of the Secret rendered for the given MavenSetting CR. A Secret
+// is used rather than a ConfigMap because the rendered settings.xml embeds the server
+// credentials resolved from Spec.Servers[].SecretRef.
+func SecretName(ms *rainbondv1alpha1.MavenSetting) string {
+	return fmt.Sprintf("mavensetting-%s", ms.Name)
+}
+
+// resolvedServer carries a MavenServer's credentials once they have been read out of the
+// Secret its SecretRef points at, so settings.xml rendering never has to talk to the API
+// server itself.
+type resolvedServer struct {
+	ID       string
+	Username string
+	Password string
+}
+
+// resolveServers fetches the Secret referenced by each MavenServer and reads its
+// "username"/"password" keys. Shared by the controller and the admission webhook so both
+// validate/render against the same resolved credentials.
+func resolveServers(ctx context.Context, cli client.Client, namespace string, servers []rainbondv1alpha1.MavenServer) ([]resolvedServer, error) {
+	resolved := make([]resolvedServer, 0, len(servers))
+	for _, s := range servers {
+		secret := &corev1.Secret{}
+		if err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: s.SecretRef.Name}, secret); err != nil {
+			return nil, fmt.Errorf("get secret %q for maven server %q: %v", s.SecretRef.Name, s.ID, err)
+		}
+		resolved = append(resolved, resolvedServer{
+			ID:       s.ID,
+			Username: string(secret.Data["username"]),
+			Password: string(secret.Data["password"]),
+		})
+	}
+	return resolved, nil
+}
+
+// settingsData is the root object the settings.xml template renders, combining the CR's
+// mirrors/profiles with servers whose credentials have already been resolved.
+type settingsData struct {
+	Servers        []resolvedServer
+	Mirrors        []rainbondv1alpha1.MavenMirror
+	Profiles       []rainbondv1alpha1.MavenProfile
+	ActiveProfiles []string
+}
+
+// SecretFor builds the Secret consumed by builds, holding the MavenSetting CR rendered to
+// settings.xml with server credentials already resolved from their secretRef.
+func SecretFor(namespace string, ms *rainbondv1alpha1.MavenSetting, servers []resolvedServer) (*corev1.Secret, error) {
+	xml, err := RenderSettingsXML(ms, servers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      SecretName(ms),
+			Namespace: namespace,
+			Labels: rbdutil.LabelsForRainbond(map[string]string{
+				"configtype": "mavensetting",
+				"default":    fmt.Sprintf("%t", ms.Spec.Default),
+			}),
+		},
+		Data: map[string][]byte{
+			"mavensetting": []byte(xml),
+		},
+	}, nil
+}
+
+const settingsTemplate = `<settings xmlns="http://maven.apache.org/SETTINGS/1.0.0"
+  xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+  xsi:schemaLocation="http://maven.apache.org/SETTINGS/1.0.0
+                      http://maven.apache.org/xsd/settings-1.0.0.xsd">
+  <servers>
+  {{- range .Servers }}
+    <server>
+      <id>{{ .ID }}</id>
+      <username>{{ .Username }}</username>
+      <password>{{ .Password }}</password>
+    </server>
+  {{- end }}
+  </servers>
+  <mirrors>
+  {{- range .Mirrors }}
+    <mirror>
+      <id>{{ .ID }}</id>
+      <mirrorOf>{{ .MirrorOf }}</mirrorOf>
+      <name>{{ .Name }}</name>
+      <url>{{ .URL }}</url>
+    </mirror>
+  {{- end }}
+  </mirrors>
+  <profiles>
+  {{- range .Profiles }}
+    <profile>
+      <id>{{ .ID }}</id>
+      <repositories>
+      {{- range .Repositories }}
+        <repository>
+          <id>{{ .ID }}</id>
+          <url>{{ .URL }}</url>
+        </repository>
+      {{- end }}
+      </repositories>
+    </profile>
+  {{- end }}
+  </profiles>
+  <activeProfiles>
+  {{- range .ActiveProfiles }}
+    <activeProfile>{{ . }}</activeProfile>
+  {{- end }}
+  </activeProfiles>
+</settings>
+`
+
+// settingsTpl uses html/template rather than text/template so resolved usernames/passwords
+// are escaped before landing in the XML text nodes, since they come from a Secret the CR
+// author doesn't control the contents of.
+var settingsTpl = template.Must(template.New("mavensettings").Parse(settingsTemplate))
+
+// RenderSettingsXML renders a MavenSetting CR's mirrors/profiles, plus already-resolved
+// server credentials, into a settings.xml document.
+func RenderSettingsXML(ms *rainbondv1alpha1.MavenSetting, servers []resolvedServer) (string, error) {
+	var buf bytes.Buffer
+	data := settingsData{
+		Servers:        servers,
+		Mirrors:        ms.Spec.Mirrors,
+		Profiles:       ms.Spec.Profiles,
+		ActiveProfiles: ms.Spec.ActiveProfiles,
+	}
+	if err := settingsTpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("render maven settings.xml for %s: %v", ms.Name, err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,58 @@
+package mavensetting
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+
+	rainbondv1alpha1 "github.com/goodrain/rainbond-operator/pkg/apis/rainbond/v1alpha1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// Validator rejects MavenSetting CRs whose mirrors/servers/profiles would render into
+// malformed settings.xml, or whose server secretRefs don't resolve, so a bad CR never reaches
+// the mavensetting controller's Secret rendering.
+type Validator struct {
+	client  client.Client
+	decoder *admission.Decoder
+}
+
+var _ admission.Handler = &Validator{}
+
+// Handle implements admission.Handler.
+func (v *Validator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	ms := &rainbondv1alpha1.MavenSetting{}
+	if err := v.decoder.Decode(req, ms); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	servers, err := resolveServers(ctx, v.client, req.Namespace, ms.Spec.Servers)
+	if err != nil {
+		return admission.Denied(fmt.Sprintf("resolve server secretRef: %v", err))
+	}
+
+	rendered, err := RenderSettingsXML(ms, servers)
+	if err != nil {
+		return admission.Denied(err.Error())
+	}
+	if err := xml.Unmarshal([]byte(rendered), new(struct{})); err != nil {
+		return admission.Denied("rendered settings.xml is not well-formed: " + err.Error())
+	}
+
+	return admission.Allowed("")
+}
+
+// InjectDecoder injects the decoder. A decoder will be automatically injected.
+func (v *Validator) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
+
+// InjectClient injects the client. A client will be automatically injected.
+func (v *Validator) InjectClient(c client.Client) error {
+	v.client = c
+	return nil
+}
@@ -0,0 +1,10 @@
+package rainbondchaosexperiment
+
+import (
+	"github.com/goodrain/rainbond-operator/pkg/controller"
+)
+
+func init() {
+	// AddToManagerFuncs is a list of functions to create controllers and add them to a manager.
+	controller.AddToManagerFuncs = append(controller.AddToManagerFuncs, Add)
+}
@@ -0,0 +1,144 @@
+package rainbondchaosexperiment
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	rainbondv1alpha1 "github.com/goodrain/rainbond-operator/pkg/apis/rainbond/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDueToRunOneShotNeverRun(t *testing.T) {
+	due, requeueAfter, err := dueToRun("", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !due {
+		t.Fatal("a one-shot experiment that has never run should be due now")
+	}
+	if requeueAfter != 0 {
+		t.Fatalf("expected no requeueAfter for a due run, got %v", requeueAfter)
+	}
+}
+
+func TestDueToRunOneShotAlreadyRun(t *testing.T) {
+	last := metav1.Now()
+	due, _, err := dueToRun("", &last)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if due {
+		t.Fatal("a one-shot experiment that already ran should not be due again")
+	}
+}
+
+func TestDueToRunCronScheduleNeverRun(t *testing.T) {
+	// The epoch sentinel used for "never run" is far enough in the past that the next
+	// scheduled tick for any cron expression has already passed "now".
+	due, requeueAfter, err := dueToRun("* * * * *", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !due {
+		t.Fatal("expected a never-run recurring experiment to be due")
+	}
+	if requeueAfter != 0 {
+		t.Fatalf("expected no requeueAfter for a due run, got %v", requeueAfter)
+	}
+}
+
+func TestDueToRunCronScheduleNotYetDue(t *testing.T) {
+	last := metav1.Now()
+	due, requeueAfter, err := dueToRun("0 0 1 1 *", &last)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if due {
+		t.Fatal("expected a once-a-year schedule that just ran to not be due")
+	}
+	if requeueAfter <= 0 {
+		t.Fatalf("expected a positive requeueAfter, got %v", requeueAfter)
+	}
+}
+
+func TestDueToRunInvalidSchedule(t *testing.T) {
+	if _, _, err := dueToRun("not a schedule", nil); err == nil {
+		t.Fatal("expected an error for an invalid cron schedule")
+	}
+}
+
+func TestEvaluateHTTPGetProbeNoPodsMatched(t *testing.T) {
+	probe := rainbondv1alpha1.Probe{
+		Name:    "health",
+		HTTPGet: &rainbondv1alpha1.HTTPGetProbe{Path: "/healthz", Port: 80},
+	}
+	result := evaluateHTTPGetProbe(probe, nil)
+	if result.Success {
+		t.Fatal("expected failure when appSelector matched no pods")
+	}
+}
+
+func TestEvaluateHTTPGetProbeMissingSpec(t *testing.T) {
+	result := evaluateHTTPGetProbe(rainbondv1alpha1.Probe{Name: "health"}, []corev1.Pod{{}})
+	if result.Success {
+		t.Fatal("expected failure when the probe has no httpGet spec")
+	}
+}
+
+func TestEvaluateHTTPGetProbeSuccess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	host, port := splitHostPort(t, server.Listener.Addr().String())
+	probe := rainbondv1alpha1.Probe{
+		Name:    "health",
+		HTTPGet: &rainbondv1alpha1.HTTPGetProbe{Path: "/", Port: port},
+	}
+	pods := []corev1.Pod{{Status: corev1.PodStatus{PodIP: host}}}
+
+	result := evaluateHTTPGetProbe(probe, pods)
+	if !result.Success {
+		t.Fatalf("expected success, got message %q", result.Message)
+	}
+}
+
+func TestEvaluateHTTPGetProbeWrongStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	host, port := splitHostPort(t, server.Listener.Addr().String())
+	probe := rainbondv1alpha1.Probe{
+		Name:    "health",
+		HTTPGet: &rainbondv1alpha1.HTTPGetProbe{Path: "/", Port: port},
+	}
+	pods := []corev1.Pod{{Status: corev1.PodStatus{PodIP: host}}}
+
+	result := evaluateHTTPGetProbe(probe, pods)
+	if result.Success {
+		t.Fatal("expected failure for a non-matching status code")
+	}
+}
+
+// splitHostPort pulls the host and numeric port out of an httptest server's listener address,
+// since HTTPGetProbe carries the port as a separate int32 field rather than a "host:port" pair.
+func splitHostPort(t *testing.T, addr string) (string, int32) {
+	t.Helper()
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		t.Fatalf("split listener addr %q: %v", addr, err)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		t.Fatalf("parse port %q: %v", portStr, err)
+	}
+	return host, int32(port)
+}
@@ -15,8 +15,13 @@ import (
 	"github.com/goodrain/rainbond-operator/pkg/util/constants"
 	"github.com/goodrain/rainbond-operator/pkg/util/k8sutil"
 
+	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -25,6 +30,58 @@ import (
 // ChaosName name for rbd-chaos
 var ChaosName = "rbd-chaos"
 
+// buildkitdName is the name given to the buildkitd sidecar container and its socket volume.
+const buildkitdName = "buildkitd"
+
+// buildkitSockDir is the emptyDir path shared between rbd-chaos and the buildkitd sidecar
+// over which the buildkit gRPC socket is exposed.
+const buildkitSockDir = "/run/buildkitd"
+
+// chaosMetricsPort is the port rbd-chaos serves /metrics on, separate from the API port 3228
+// that the readiness and liveness probes check.
+const chaosMetricsPort = 3229
+
+// kanikoExecutorImage is the Kaniko executor image stamped into each per-build Job that
+// KanikoJobTemplate produces.
+const kanikoExecutorImage = "gcr.io/kaniko-project/executor:latest"
+
+// KanikoJobTemplate returns the one-shot Job rbd-chaos creates for a single build when
+// BuildBackend is kaniko, in place of the long-lived buildkitd sidecar the buildkit backend
+// uses: Kaniko has no daemon to keep warm between builds, so each build gets its own rootless
+// Job instead of sharing a socket.
+func KanikoJobTemplate(namespace, name, contextDir, destination string) *batchv1.Job {
+	backoffLimit := int32(0)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{
+						{
+							Name:  "kaniko",
+							Image: kanikoExecutorImage,
+							Args: []string{
+								"--context=" + contextDir,
+								"--destination=" + destination,
+							},
+							SecurityContext: &corev1.SecurityContext{
+								RunAsUser:    commonutil.Int64(1000),
+								RunAsGroup:   commonutil.Int64(1000),
+								RunAsNonRoot: commonutil.Bool(true),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 type chaos struct {
 	ctx        context.Context
 	client     client.Client
@@ -37,6 +94,12 @@ type chaos struct {
 	pvcParametersRWX     *pvcParameters
 	cacheStorageRequest  int64
 	grdataStorageRequest int64
+
+	mavenSettings []rainbondv1alpha1.MavenSetting
+
+	storageProfiles []rainbondv1alpha1.StorageProfile
+
+	hasServiceMonitorCRD bool
 }
 
 var _ ComponentHandler = &chaos{}
@@ -76,18 +139,82 @@ func (c *chaos) Before() error {
 		return err
 	}
 
+	mavenSettingList := &rainbondv1alpha1.MavenSettingList{}
+	if err := c.client.List(c.ctx, mavenSettingList, client.InNamespace(c.component.Namespace)); err != nil {
+		return fmt.Errorf("list maven settings: %v", err)
+	}
+	c.mavenSettings = mavenSettingList.Items
+
+	storageProfileList := &rainbondv1alpha1.StorageProfileList{}
+	if err := c.client.List(c.ctx, storageProfileList, client.InNamespace(c.component.Namespace)); err != nil {
+		return fmt.Errorf("list storage profiles: %v", err)
+	}
+	c.storageProfiles = storageProfileList.Items
+
+	hasServiceMonitorCRD, err := k8sutil.RESTMapperHasKind(c.ctx, c.client.RESTMapper(), monitoringv1.SchemeGroupVersion.WithKind(monitoringv1.ServiceMonitorsKind))
+	if err != nil {
+		return fmt.Errorf("detect prometheus-operator CRDs: %v", err)
+	}
+	c.hasServiceMonitorCRD = hasServiceMonitorCRD
+
+	if c.cluster.Spec.ContainerRuntime == "" && c.cluster.Status.ContainerRuntime == "" {
+		runtime, err := detectContainerRuntime(c.ctx, c.client, c.cluster)
+		if err != nil {
+			return fmt.Errorf("detect container runtime: %v", err)
+		}
+		c.cluster.Status.ContainerRuntime = runtime
+		if err := c.client.Status().Update(c.ctx, c.cluster); err != nil {
+			return fmt.Errorf("update container runtime status: %v", err)
+		}
+	}
+
 	return nil
 }
 
+// detectContainerRuntime inspects the kubelet-reported container runtime version on the
+// cluster's nodes, so clusters that have migrated off dockershim don't need
+// ContainerRuntime set by hand.
+func detectContainerRuntime(ctx context.Context, cli client.Client, cluster *rainbondv1alpha1.RainbondCluster) (rainbondv1alpha1.ContainerRuntime, error) {
+	nodes := &corev1.NodeList{}
+	if err := cli.List(ctx, nodes); err != nil {
+		return "", err
+	}
+	for _, node := range nodes.Items {
+		switch {
+		case strings.Contains(node.Status.NodeInfo.ContainerRuntimeVersion, "containerd"):
+			return rainbondv1alpha1.ContainerRuntimeContainerd, nil
+		case strings.Contains(node.Status.NodeInfo.ContainerRuntimeVersion, "cri-o"):
+			return rainbondv1alpha1.ContainerRuntimeCRIO, nil
+		case strings.Contains(node.Status.NodeInfo.ContainerRuntimeVersion, "docker"):
+			return rainbondv1alpha1.ContainerRuntimeDocker, nil
+		}
+	}
+	return rainbondv1alpha1.ContainerRuntimeDocker, nil
+}
+
 func (c *chaos) Resources() []interface{} {
-	return []interface{}{
+	res := []interface{}{
 		c.deployment(),
 		c.service(),
-		c.defaultMavenSetting(),
 	}
+	res = append(res, c.mavenSettingConfigMaps()...)
+	if c.hasServiceMonitorCRD {
+		res = append(res, c.serviceMonitor())
+	}
+	return res
 }
 
 func (c *chaos) After() error {
+	for _, profile := range c.storageProfiles {
+		usage, err := pvcUsage(c.ctx, c.client, c.component.Namespace, profile.Name)
+		// The tenant's cache PVC may not exist yet, or may still be unbound, on the
+		// first reconcile after its StorageProfile is created. Treat that as zero
+		// usage rather than failing the whole component's reconcile.
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("get pvc usage for storage profile %s: %v", profile.Name, err)
+		}
+		c.component.Status.StorageUsage = mergeStorageUsage(c.component.Status.StorageUsage, profile.Name, usage)
+	}
 	return nil
 }
 func (c *chaos) ListPods() ([]corev1.Pod, error) {
@@ -98,27 +225,110 @@ func (c *chaos) SetStorageClassNameRWX(pvcParametersRWX *pvcParameters) {
 	c.pvcParametersRWX = pvcParametersRWX
 }
 
+// ResourcesCreateIfNotExists creates one grdata/cache PVC pair per StorageProfile found in
+// the component's namespace, labeled with the owning tenant and sized from the profile's own
+// CacheSize/GrDataSize. The cap is enforced on each tenant's PVC directly rather than through
+// a namespace-wide ResourceQuota: a requests.storage ResourceQuota cannot distinguish one
+// tenant's PVCs from another's once more than one StorageProfile shares the namespace, so it
+// would cap every tenant down to the smallest configured size instead of enforcing each
+// tenant's own limit. When no StorageProfile exists it falls back to the single
+// cluster-wide PVC pair.
 func (c *chaos) ResourcesCreateIfNotExists() []interface{} {
-	return []interface{}{
-		createPersistentVolumeClaimRWX(c.component.Namespace, constants.GrDataPVC, c.pvcParametersRWX, c.labels),
-		createPersistentVolumeClaimRWX(c.component.Namespace, constants.CachePVC, c.pvcParametersRWX, c.labels),
+	if len(c.storageProfiles) == 0 {
+		return []interface{}{
+			createPersistentVolumeClaimRWX(c.component.Namespace, constants.GrDataPVC, c.pvcParametersRWX, c.labels),
+			createPersistentVolumeClaimRWX(c.component.Namespace, constants.CachePVC, c.pvcParametersRWX, c.labels),
+		}
+	}
+
+	var res []interface{}
+	for _, profile := range c.storageProfiles {
+		labels := tenantLabels(c.labels, profile.Name)
+		grdata := createPersistentVolumeClaimRWX(c.component.Namespace, tenantPVCName(constants.GrDataPVC, profile.Name), c.pvcParametersRWX, labels)
+		cache := createPersistentVolumeClaimRWX(c.component.Namespace, tenantPVCName(constants.CachePVC, profile.Name), c.pvcParametersRWX, labels)
+		res = append(res,
+			withStorageRequest(grdata, profile.Spec.GrDataSize),
+			withStorageRequest(cache, profile.Spec.CacheSize),
+		)
+	}
+	return res
+}
+
+// withStorageRequest overrides the storage request on a PVC built by
+// createPersistentVolumeClaimRWX with size, so each tenant's PVC is capped at its own
+// StorageProfile size.
+func withStorageRequest(obj interface{}, size resource.Quantity) interface{} {
+	pvc, ok := obj.(*corev1.PersistentVolumeClaim)
+	if !ok {
+		return obj
+	}
+	if pvc.Spec.Resources.Requests == nil {
+		pvc.Spec.Resources.Requests = corev1.ResourceList{}
+	}
+	pvc.Spec.Resources.Requests[corev1.ResourceStorage] = size
+	return pvc
+}
+
+func tenantPVCName(base, tenant string) string {
+	return fmt.Sprintf("%s-%s", base, tenant)
+}
+
+// pvcUsage reads the cache PVC's reported capacity for a tenant so it can be surfaced on
+// RbdComponent.Status; it is capacity rather than live usage since the kubelet does not
+// report per-PVC usage through the API server.
+func pvcUsage(ctx context.Context, cli client.Client, namespace, tenant string) (resource.Quantity, error) {
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: tenantPVCName(constants.CachePVC, tenant)}, pvc); err != nil {
+		return resource.Quantity{}, err
+	}
+	return pvc.Status.Capacity[corev1.ResourceStorage], nil
+}
+
+func mergeStorageUsage(existing []rainbondv1alpha1.TenantStorageUsage, tenant string, used resource.Quantity) []rainbondv1alpha1.TenantStorageUsage {
+	for i := range existing {
+		if existing[i].Tenant == tenant {
+			existing[i].Used = used
+			return existing
+		}
 	}
+	return append(existing, rainbondv1alpha1.TenantStorageUsage{Tenant: tenant, Used: used})
+}
+
+func tenantLabels(labels map[string]string, tenant string) map[string]string {
+	merged := make(map[string]string, len(labels)+1)
+	for k, v := range labels {
+		merged[k] = v
+	}
+	merged["tenant"] = tenant
+	return merged
 }
 
 func (c *chaos) Replicas() *int32 {
 	return commonutil.Int32(int32(len(c.cluster.Spec.NodesForChaos)))
 }
 
+// containerRuntimeSockPath returns the host socket rbd-chaos should mount for the given
+// container runtime, honoring an operator-supplied override.
+func containerRuntimeSockPath(runtime rainbondv1alpha1.ContainerRuntime, override string) string {
+	if override != "" {
+		return override
+	}
+	switch runtime {
+	case rainbondv1alpha1.ContainerRuntimeContainerd:
+		return "/run/containerd/containerd.sock"
+	case rainbondv1alpha1.ContainerRuntimeCRIO:
+		return "/var/run/crio/crio.sock"
+	default:
+		return "/var/run/docker.sock"
+	}
+}
+
 func (c *chaos) deployment() interface{} {
 	volumeMounts := []corev1.VolumeMount{
 		{
 			Name:      "grdata",
 			MountPath: "/grdata",
 		},
-		{
-			Name:      "dockersock",
-			MountPath: "/var/run/docker.sock",
-		},
 		{
 			Name:      "cache",
 			MountPath: "/cache",
@@ -138,15 +348,6 @@ func (c *chaos) deployment() interface{} {
 				},
 			},
 		},
-		{
-			Name: "dockersock",
-			VolumeSource: corev1.VolumeSource{
-				HostPath: &corev1.HostPathVolumeSource{
-					Path: "/var/run/docker.sock",
-					Type: k8sutil.HostPath(corev1.HostPathSocket),
-				},
-			},
-		},
 		{
 			Name: "cache",
 			VolumeSource: corev1.VolumeSource{
@@ -166,6 +367,73 @@ func (c *chaos) deployment() interface{} {
 		"--rbd-repo=" + ResourceProxyName,
 	}
 
+	var buildkitdSidecar *corev1.Container
+	switch c.component.Spec.BuildBackend {
+	case rainbondv1alpha1.BuildBackendBuildkit:
+		// buildkitd runs as a long-lived rootless sidecar, reused across builds, with its
+		// gRPC socket shared into the rbd-chaos container over an emptyDir.
+		endpoint := "unix://" + path.Join(buildkitSockDir, "buildkitd.sock")
+		args = append(args, "--build-backend="+string(c.component.Spec.BuildBackend), "--buildkit-endpoint="+endpoint)
+
+		buildkitSockVolume := corev1.Volume{
+			Name: buildkitdName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		}
+		buildkitSockMount := corev1.VolumeMount{
+			Name:      buildkitdName,
+			MountPath: buildkitSockDir,
+		}
+		volumes = append(volumes, buildkitSockVolume)
+		volumeMounts = append(volumeMounts, buildkitSockMount)
+
+		buildkitdSidecar = &corev1.Container{
+			Name:  buildkitdName,
+			Image: "moby/buildkit:rootless",
+			Args:  []string{"--addr", endpoint, "--oci-worker-no-process-sandbox"},
+			SecurityContext: &corev1.SecurityContext{
+				RunAsUser:    commonutil.Int64(1000),
+				RunAsGroup:   commonutil.Int64(1000),
+				RunAsNonRoot: commonutil.Bool(true),
+			},
+			VolumeMounts: []corev1.VolumeMount{buildkitSockMount},
+			ReadinessProbe: &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{
+					Exec: &corev1.ExecAction{
+						Command: []string{"buildctl", "--addr", endpoint, "debug", "workers"},
+					},
+				},
+			},
+		}
+	case rainbondv1alpha1.BuildBackendKaniko:
+		// Kaniko has no daemon to talk to: rbd-chaos creates one KanikoJobTemplate Job per
+		// build instead of keeping a sidecar warm, so there is no socket/endpoint to pass in
+		// here, only the executor image that template uses.
+		args = append(args, "--build-backend="+string(c.component.Spec.BuildBackend), "--kaniko-executor-image="+kanikoExecutorImage)
+	default:
+		runtime := c.cluster.Spec.ContainerRuntime
+		if runtime == "" {
+			runtime = c.cluster.Status.ContainerRuntime
+		}
+		sockPath := containerRuntimeSockPath(runtime, c.cluster.Spec.RuntimeSocketPath)
+
+		args = append(args, "--build-backend="+string(rainbondv1alpha1.BuildBackendDocker), "--container-runtime="+string(runtime))
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "dockersock",
+			MountPath: sockPath,
+		})
+		volumes = append(volumes, corev1.Volume{
+			Name: "dockersock",
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: sockPath,
+					Type: k8sutil.HostPath(corev1.HostPathSocket),
+				},
+			},
+		})
+	}
+
 	if c.etcdSecret != nil {
 		volume, mount := volumeByEtcd(c.etcdSecret)
 		volumeMounts = append(volumeMounts, mount)
@@ -179,7 +447,7 @@ func (c *chaos) deployment() interface{} {
 	}
 	var affinity *corev1.Affinity
 	if len(nodeNames) > 0 {
-		affinity = affinityForRequiredNodes(nodeNames)
+		affinity = k8sutil.AffinityForRequiredNodes(nodeNames)
 	}
 
 	env := []corev1.EnvVar{
@@ -226,6 +494,27 @@ func (c *chaos) deployment() interface{} {
 
 	// prepare probe
 	readinessProbe := probeutil.MakeReadinessProbeHTTP("", "/v2/builder/health", 3228)
+	livenessProbe := probeutil.MakeLivenessProbeHTTP("", "/v2/builder/health", 3228)
+	containers := []corev1.Container{
+		{
+			Name:            ChaosName,
+			Image:           c.component.Spec.Image,
+			ImagePullPolicy: c.component.ImagePullPolicy(),
+			Env:             env,
+			Args:            args,
+			VolumeMounts:    volumeMounts,
+			Ports: []corev1.ContainerPort{
+				{Name: "api", ContainerPort: 3228},
+				{Name: "metrics", ContainerPort: chaosMetricsPort},
+			},
+			ReadinessProbe: readinessProbe,
+			LivenessProbe:  livenessProbe,
+			Resources:      c.component.Spec.Resources,
+		},
+	}
+	if buildkitdSidecar != nil {
+		containers = append(containers, *buildkitdSidecar)
+	}
 	ds := &appsv1.DaemonSet{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      ChaosName,
@@ -252,19 +541,8 @@ func (c *chaos) deployment() interface{} {
 					},
 					HostAliases: hostsAliases(c.cluster),
 					Affinity:    affinity,
-					Containers: []corev1.Container{
-						{
-							Name:            ChaosName,
-							Image:           c.component.Spec.Image,
-							ImagePullPolicy: c.component.ImagePullPolicy(),
-							Env:             env,
-							Args:            args,
-							VolumeMounts:    volumeMounts,
-							ReadinessProbe:  readinessProbe,
-							Resources:       c.component.Spec.Resources,
-						},
-					},
-					Volumes: volumes,
+					Containers:  containers,
+					Volumes:     volumes,
 				},
 			},
 		},
@@ -289,6 +567,13 @@ func (c *chaos) service() *corev1.Service {
 						IntVal: 3228,
 					},
 				},
+				{
+					Name: "metrics",
+					Port: chaosMetricsPort,
+					TargetPort: intstr.IntOrString{
+						IntVal: chaosMetricsPort,
+					},
+				},
 			},
 			Selector: c.labels,
 		},
@@ -296,6 +581,46 @@ func (c *chaos) service() *corev1.Service {
 	return svc
 }
 
+// serviceMonitor targets rbd-chaos's metrics port for scraping when the Prometheus Operator
+// CRDs are installed in the cluster, using labels compatible with kube-prometheus's default
+// ServiceMonitor selector.
+func (c *chaos) serviceMonitor() *monitoringv1.ServiceMonitor {
+	return &monitoringv1.ServiceMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ChaosName,
+			Namespace: c.component.Namespace,
+			Labels:    rbdutil.LabelsForRainbond(map[string]string{"release": "prometheus-operator"}),
+		},
+		Spec: monitoringv1.ServiceMonitorSpec{
+			Selector: metav1.LabelSelector{
+				MatchLabels: c.labels,
+			},
+			NamespaceSelector: monitoringv1.NamespaceSelector{
+				MatchNames: []string{c.component.Namespace},
+			},
+			Endpoints: []monitoringv1.Endpoint{
+				{
+					Port:     "metrics",
+					Path:     "/metrics",
+					Interval: "30s",
+				},
+			},
+		},
+	}
+}
+
+// mavenSettingConfigMaps returns the baked-in Aliyun-mirrored settings.xml ConfigMap when no
+// MavenSetting CR exists in the component's namespace, so upgrades from older installs keep
+// working unchanged. Once a MavenSetting CR exists, the dedicated mavensetting controller
+// renders and owns its settings.xml Secret instead; rbd-chaos must not also manage that
+// object, so it returns nothing for that case.
+func (c *chaos) mavenSettingConfigMaps() []interface{} {
+	if len(c.mavenSettings) == 0 {
+		return []interface{}{c.defaultMavenSetting()}
+	}
+	return nil
+}
+
 func (c *chaos) defaultMavenSetting() *corev1.ConfigMap {
 	var mavensetting = `<settings xmlns="http://maven.apache.org/SETTINGS/1.0.0"
   xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
@@ -0,0 +1,29 @@
+package k8sutil
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// AffinityForRequiredNodes returns an Affinity that hard-requires scheduling onto one of
+// nodeNames, matched by kubernetes.io/hostname. It is shared by rbd-chaos and the chaos
+// experiment Pods the rainbondchaosexperiment controller creates, so both are pinned to the
+// same set of nodes reserved for chaos workloads.
+func AffinityForRequiredNodes(nodeNames []string) *corev1.Affinity {
+	return &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{
+						MatchExpressions: []corev1.NodeSelectorRequirement{
+							{
+								Key:      "kubernetes.io/hostname",
+								Operator: corev1.NodeSelectorOpIn,
+								Values:   nodeNames,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}